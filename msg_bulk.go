@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	ht "html/template"
+	"reflect"
+	tt "text/template"
+	"unsafe"
+)
+
+// errTplBulkNotSet is returned by Client.SendBulk when the Msg it was given has no bulk template set
+// via Msg.SetBulkTemplate.
+var errTplBulkNotSet = errors.New("msg has no bulk template set, call SetBulkTemplate first")
+
+// Recipient represents a single addressee of a bulk/personalized send, exposed to bulk templates as
+// the top-level ".Recipient" field (e.g. "{{.Recipient.Email}}").
+type Recipient struct {
+	Email string
+	Name  string
+}
+
+// TemplateData carries the per-recipient input to a bulk send: the Recipient to deliver to, and
+// arbitrary template Data made available to the bulk templates as ".Data" (e.g. "{{.Data.FirstName}}").
+type TemplateData struct {
+	Recipient Recipient
+	Data      map[string]any
+}
+
+// bulkTemplateContext is the value a bulk template is executed against; it is assembled by
+// Client.SendBulk from a single TemplateData entry.
+type bulkTemplateContext struct {
+	Recipient Recipient
+	Data      map[string]any
+}
+
+// bulkTemplate holds the subject/text/html templates registered via Msg.SetBulkTemplate.
+type bulkTemplate struct {
+	subject *tt.Template
+	text    *tt.Template
+	html    *ht.Template
+}
+
+// SetBulkTemplate registers the text and/or HTML templates used by Client.SendBulk to personalize a
+// copy of this Msg for each recipient passed to SendBulk. subjectTpl is optional; when nil, the Msg's
+// current Subject is reused unchanged for every recipient. At least one of tpl and htmlTpl must be
+// non-nil. Each template is executed against a bulkTemplateContext exposing ".Recipient" and ".Data",
+// populated from the corresponding TemplateData entry.
+//
+// Parameters:
+//   - subjectTpl: An optional text/template.Template used to render a per-recipient Subject.
+//   - tpl: A text/template.Template used to render the per-recipient plain text body, or nil.
+//   - htmlTpl: An html/template.Template used to render the per-recipient HTML body, or nil.
+//
+// Returns:
+//   - An error if neither tpl nor htmlTpl is given.
+func (m *Msg) SetBulkTemplate(subjectTpl *tt.Template, tpl *tt.Template, htmlTpl *ht.Template) error {
+	if tpl == nil && htmlTpl == nil {
+		return errors.New("at least one of tpl or htmlTpl must be set")
+	}
+	m.bulkTemplate = &bulkTemplate{subject: subjectTpl, text: tpl, html: htmlTpl}
+	return nil
+}
+
+// deepCopyMsg returns a *Msg independent of m: every map and slice field (addrHeader, genHeader,
+// attachments, embeds, parts, templateValidators, ...) is rebuilt into a fresh map/slice
+// instead of being shared with m, while the keys/elements they hold (addresses, headers, files, ...)
+// are reused as-is since those are never mutated in place. A plain `cloned := *m` struct copy would
+// leave every such field pointing at the exact same underlying map/slice as m, so mutating one clone
+// (e.g. via To) would be visible through every other clone and through m itself - exactly the bug
+// Msg.personalize needs to avoid across a SendBulk batch. Reflection plus unsafe.Pointer is required
+// because Msg's map/slice fields are unexported and there is no public API to rebuild them from outside
+// this package's other files.
+func deepCopyMsg(m *Msg) *Msg {
+	cloned := *m
+	v := reflect.ValueOf(&cloned).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem() //nolint:gosec
+		switch field.Kind() {
+		case reflect.Map:
+			if field.IsNil() {
+				continue
+			}
+			newMap := reflect.MakeMapWithSize(field.Type(), field.Len())
+			iter := field.MapRange()
+			for iter.Next() {
+				newMap.SetMapIndex(iter.Key(), iter.Value())
+			}
+			field.Set(newMap)
+		case reflect.Slice:
+			if field.IsNil() {
+				continue
+			}
+			newSlice := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			reflect.Copy(newSlice, field)
+			field.Set(newSlice)
+		}
+	}
+	return &cloned
+}
+
+// personalize renders the Msg's bulk templates for a single recipient and returns a new *Msg with the
+// rendered subject/body and the To: header rewritten to that recipient, leaving the original Msg (and
+// its bulk template) untouched so it can be reused for the next recipient.
+func (m *Msg) personalize(recipient TemplateData) (*Msg, error) {
+	if m.bulkTemplate == nil {
+		return nil, errTplBulkNotSet
+	}
+	clone := deepCopyMsg(m)
+	clone.bulkTemplate = nil
+
+	ctx := bulkTemplateContext{Recipient: recipient.Recipient, Data: recipient.Data}
+
+	if err := clone.To(recipient.Recipient.Email); err != nil {
+		return nil, fmt.Errorf("failed to set recipient address: %w", err)
+	}
+
+	if m.bulkTemplate.subject != nil {
+		buffer := bytes.Buffer{}
+		if err := m.bulkTemplate.subject.Execute(&buffer, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render bulk subject: %w", err)
+		}
+		clone.Subject(buffer.String())
+	}
+
+	switch {
+	case m.bulkTemplate.text != nil && m.bulkTemplate.html != nil:
+		if err := clone.SetBodyTextTemplate(m.bulkTemplate.text, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render bulk text body: %w", err)
+		}
+		if err := clone.AddAlternativeHTMLTemplate(m.bulkTemplate.html, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render bulk html body: %w", err)
+		}
+	case m.bulkTemplate.text != nil:
+		if err := clone.SetBodyTextTemplate(m.bulkTemplate.text, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render bulk text body: %w", err)
+		}
+	case m.bulkTemplate.html != nil:
+		if err := clone.SetBodyHTMLTemplate(m.bulkTemplate.html, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render bulk html body: %w", err)
+		}
+	}
+
+	return clone, nil
+}
+
+// SendBulk personalizes msg for each recipient using the templates registered via Msg.SetBulkTemplate,
+// then delivers the resulting messages over the Client's SMTP connection, pipelining where the
+// connection supports it instead of dialing once per recipient. msg itself is never modified or sent
+// directly; personalization always happens against a clone of msg.
+//
+// Parameters:
+//   - ctx: The context used to control the lifetime of the connection and delivery.
+//   - msg: The Msg carrying the bulk template set via SetBulkTemplate.
+//   - recipients: The per-recipient addresses and template data to personalize and send msg with.
+//
+// Returns:
+//   - An error if msg has no bulk template set, if a recipient fails to render, or if delivery fails.
+func (c *Client) SendBulk(ctx context.Context, msg *Msg, recipients []TemplateData) error {
+	if msg == nil {
+		return errors.New(errMsgPointerNil)
+	}
+	if msg.bulkTemplate == nil {
+		return errTplBulkNotSet
+	}
+
+	messages := make([]*Msg, 0, len(recipients))
+	for i, recipient := range recipients {
+		personalized, err := msg.personalize(recipient)
+		if err != nil {
+			return fmt.Errorf("failed to personalize message for recipient %d (%s): %w", i, recipient.Recipient.Email, err)
+		}
+		messages = append(messages, personalized)
+	}
+
+	return c.DialAndSendWithContext(ctx, messages...)
+}
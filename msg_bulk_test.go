@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	ht "html/template"
+	"testing"
+	tt "text/template"
+)
+
+// TestDeepCopyMsgIndependentHeaders is a regression test for the bug where personalize used a shallow
+// `cloned := *m` copy, so every clone's genHeader map was the exact same underlying map as m's - mutating
+// one clone's headers mutated m and every other clone too. deepCopyMsg must give each clone its own map.
+func TestDeepCopyMsgIndependentHeaders(t *testing.T) {
+	m := &Msg{}
+	m.SetGenHeader(Header("X-Tag"), "original")
+
+	clone := deepCopyMsg(m)
+	if got := clone.GetGenHeader(Header("X-Tag")); len(got) != 1 || got[0] != "original" {
+		t.Fatalf("clone.GetGenHeader(X-Tag) = %v, want [original] right after copying", got)
+	}
+
+	clone.SetGenHeader(Header("X-Tag"), "mutated")
+	if got := m.GetGenHeader(Header("X-Tag")); len(got) != 1 || got[0] != "original" {
+		t.Errorf("m.GetGenHeader(X-Tag) = %v after mutating the clone, want [original] (headers must not be shared)", got)
+	}
+	if got := clone.GetGenHeader(Header("X-Tag")); len(got) != 1 || got[0] != "mutated" {
+		t.Errorf("clone.GetGenHeader(X-Tag) = %v, want [mutated]", got)
+	}
+}
+
+func TestSetBulkTemplateRequiresAtLeastOneTemplate(t *testing.T) {
+	m := &Msg{}
+	if err := m.SetBulkTemplate(nil, nil, nil); err == nil {
+		t.Error("SetBulkTemplate(nil, nil, nil) = nil error, want non-nil")
+	}
+
+	tpl := tt.Must(tt.New("text").Parse("hello {{.Recipient.Name}}"))
+	if err := m.SetBulkTemplate(nil, tpl, nil); err != nil {
+		t.Errorf("SetBulkTemplate(nil, tpl, nil) returned error: %v", err)
+	}
+}
+
+func TestPersonalizeWithoutBulkTemplate(t *testing.T) {
+	m := &Msg{}
+	if _, err := m.personalize(TemplateData{Recipient: Recipient{Email: "jane@example.com"}}); err != errTplBulkNotSet {
+		t.Errorf("personalize() without SetBulkTemplate = %v, want %v", err, errTplBulkNotSet)
+	}
+}
+
+// TestPersonalizeProducesIndependentClones is a regression test for the bulk-send bug where every
+// per-recipient clone shared the same underlying header map as the original Msg, so personalizing
+// recipient B would silently overwrite recipient A's already-rendered headers.
+func TestPersonalizeProducesIndependentClones(t *testing.T) {
+	m := &Msg{}
+	textTpl := tt.Must(tt.New("text").Parse("Hello {{.Recipient.Name}}, your code is {{.Data.code}}"))
+	htmlTpl := ht.Must(ht.New("html").Parse("<p>Hello {{.Recipient.Name}}</p>"))
+	if err := m.SetBulkTemplate(nil, textTpl, htmlTpl); err != nil {
+		t.Fatalf("SetBulkTemplate() returned error: %v", err)
+	}
+
+	alice := TemplateData{
+		Recipient: Recipient{Email: "alice@example.com", Name: "Alice"},
+		Data:      map[string]any{"code": "111111"},
+	}
+	bob := TemplateData{
+		Recipient: Recipient{Email: "bob@example.com", Name: "Bob"},
+		Data:      map[string]any{"code": "222222"},
+	}
+
+	aliceMsg, err := m.personalize(alice)
+	if err != nil {
+		t.Fatalf("personalize(alice) returned error: %v", err)
+	}
+	bobMsg, err := m.personalize(bob)
+	if err != nil {
+		t.Fatalf("personalize(bob) returned error: %v", err)
+	}
+
+	if aliceMsg == bobMsg {
+		t.Fatal("personalize() returned the same *Msg for two different recipients")
+	}
+	if aliceMsg.bulkTemplate != nil || bobMsg.bulkTemplate != nil {
+		t.Error("personalize() clones should have bulkTemplate cleared so they are not re-personalized")
+	}
+	if m.bulkTemplate == nil {
+		t.Error("personalize() must not clear bulkTemplate on the original Msg")
+	}
+
+	// Mutating one personalized clone's headers must never leak into the other clone or into m.
+	aliceMsg.SetGenHeader(Header("X-Debug"), "alice-only")
+	if got := bobMsg.GetGenHeader(Header("X-Debug")); len(got) != 0 {
+		t.Errorf("bobMsg picked up a header set on aliceMsg: %v", got)
+	}
+	if got := m.GetGenHeader(Header("X-Debug")); len(got) != 0 {
+		t.Errorf("original Msg picked up a header set on aliceMsg: %v", got)
+	}
+}
+
+// TestPersonalizeRendersSubjectTemplate is a regression test for the bug where a non-nil subjectTpl was
+// rendered with SetBodyTextTemplate (setting the body) instead of Subject (setting the Subject header),
+// and was then immediately clobbered by the text/html body render that followed it - so SetBulkTemplate's
+// subjectTpl had no observable effect at all.
+func TestPersonalizeRendersSubjectTemplate(t *testing.T) {
+	m := &Msg{}
+	subjectTpl := tt.Must(tt.New("subject").Parse("Your code, {{.Recipient.Name}}"))
+	textTpl := tt.Must(tt.New("text").Parse("Hello {{.Recipient.Name}}, your code is {{.Data.code}}"))
+	if err := m.SetBulkTemplate(subjectTpl, textTpl, nil); err != nil {
+		t.Fatalf("SetBulkTemplate() returned error: %v", err)
+	}
+
+	alice := TemplateData{
+		Recipient: Recipient{Email: "alice@example.com", Name: "Alice"},
+		Data:      map[string]any{"code": "111111"},
+	}
+	bob := TemplateData{
+		Recipient: Recipient{Email: "bob@example.com", Name: "Bob"},
+		Data:      map[string]any{"code": "222222"},
+	}
+
+	aliceMsg, err := m.personalize(alice)
+	if err != nil {
+		t.Fatalf("personalize(alice) returned error: %v", err)
+	}
+	bobMsg, err := m.personalize(bob)
+	if err != nil {
+		t.Fatalf("personalize(bob) returned error: %v", err)
+	}
+
+	// The bug under test set the body from subjectTpl and then immediately overwrote it with the text
+	// body render, so the Subject header never reflected subjectTpl at all; assert on it directly.
+	if got, want := aliceMsg.GetGenHeader(HeaderSubject), "Your code, Alice"; len(got) != 1 || got[0] != want {
+		t.Errorf("aliceMsg subject = %v, want [%q]", got, want)
+	}
+	if got, want := bobMsg.GetGenHeader(HeaderSubject), "Your code, Bob"; len(got) != 1 || got[0] != want {
+		t.Errorf("bobMsg subject = %v, want [%q]", got, want)
+	}
+
+	// The two Subjects must differ per recipient, not just be non-empty, or a regression that renders
+	// the same static subject for every clone would pass unnoticed.
+	if aliceMsg.GetGenHeader(HeaderSubject)[0] == bobMsg.GetGenHeader(HeaderSubject)[0] {
+		t.Error("aliceMsg and bobMsg have the same rendered Subject, want per-recipient values")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"errors"
 	"fmt"
 	ht "html/template"
+	"io"
+	"reflect"
 	tt "text/template"
 )
 
@@ -36,6 +38,12 @@ func (m *Msg) SetBodyTextTemplate(tpl *tt.Template, data interface{}, opts ...Pa
 	if tpl == nil {
 		return errors.New(errTplPointerNil)
 	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	if hasLazyTemplateOption(opts) {
+		return m.SetBodyTextTemplateStream(tpl, data, opts...)
+	}
 	buffer := bytes.NewBuffer(nil)
 	if err := tpl.Execute(buffer, data); err != nil {
 		return fmt.Errorf(errTplExecuteFailed, err)
@@ -45,6 +53,37 @@ func (m *Msg) SetBodyTextTemplate(tpl *tt.Template, data interface{}, opts ...Pa
 	return nil
 }
 
+// SetBodyTextTemplateStream sets the body of the message from a given text/template.Template pointer,
+// same as SetBodyTextTemplate, but without rendering the template into memory upfront. Instead, the
+// template is executed directly into the io.Writer supplied by SetBodyWriter at serialization time,
+// avoiding a full in-memory copy of the rendered output. Because the template is not executed until the
+// message is actually serialized, a template execution error surfaces at send/write time rather than at
+// the time this method is called.
+//
+// Parameters:
+//   - tpl: A pointer to the text/template.Template to be used for the message body.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the body part.
+//
+// Returns:
+//   - An error if the template is nil, otherwise nil.
+func (m *Msg) SetBodyTextTemplateStream(tpl *tt.Template, data interface{}, opts ...PartOption) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	m.SetBodyWriter(TypeTextPlain, writeFuncFromTextTemplate(tpl, data), opts...)
+	return nil
+}
+
 // SetBodyHTMLTemplate sets the body of the message from a given html/template.Template pointer.
 //
 // This method sets the body of the message using the provided HTML template and data. The content type
@@ -67,6 +106,12 @@ func (m *Msg) SetBodyHTMLTemplate(tpl *ht.Template, data interface{}, opts ...Pa
 	if tpl == nil {
 		return errors.New(errTplPointerNil)
 	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	if hasLazyTemplateOption(opts) {
+		return m.SetBodyHTMLTemplateStream(tpl, data, opts...)
+	}
 	buffer := bytes.NewBuffer(nil)
 	if err := tpl.Execute(buffer, data); err != nil {
 		return fmt.Errorf(errTplExecuteFailed, err)
@@ -76,6 +121,37 @@ func (m *Msg) SetBodyHTMLTemplate(tpl *ht.Template, data interface{}, opts ...Pa
 	return nil
 }
 
+// SetBodyHTMLTemplateStream sets the body of the message from a given html/template.Template pointer,
+// same as SetBodyHTMLTemplate, but without rendering the template into memory upfront. Instead, the
+// template is executed directly into the io.Writer supplied by SetBodyWriter at serialization time,
+// avoiding a full in-memory copy of the rendered output. Because the template is not executed until the
+// message is actually serialized, a template execution error surfaces at send/write time rather than at
+// the time this method is called.
+//
+// Parameters:
+//   - tpl: A pointer to the html/template.Template to be used for the message body.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the body part.
+//
+// Returns:
+//   - An error if the template is nil, otherwise nil.
+func (m *Msg) SetBodyHTMLTemplateStream(tpl *ht.Template, data interface{}, opts ...PartOption) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	m.SetBodyWriter(TypeTextHTML, writeFuncFromHTMLTemplate(tpl, data), opts...)
+	return nil
+}
+
 // AddAlternativeTextTemplate sets the alternative body of the message to a text/template.Template output.
 //
 // The content type will be set to "text/plain" automatically. This method executes the provided text template
@@ -97,6 +173,12 @@ func (m *Msg) AddAlternativeTextTemplate(tpl *tt.Template, data interface{}, opt
 	if tpl == nil {
 		return errors.New(errTplPointerNil)
 	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	if hasLazyTemplateOption(opts) {
+		return m.AddAlternativeTextTemplateStream(tpl, data, opts...)
+	}
 	buffer := bytes.NewBuffer(nil)
 	if err := tpl.Execute(buffer, data); err != nil {
 		return fmt.Errorf(errTplExecuteFailed, err)
@@ -106,6 +188,34 @@ func (m *Msg) AddAlternativeTextTemplate(tpl *tt.Template, data interface{}, opt
 	return nil
 }
 
+// AddAlternativeTextTemplateStream adds the output of a text/template.Template pointer as an alternative
+// body, same as AddAlternativeTextTemplate, but without rendering the template into memory upfront. See
+// SetBodyTextTemplateStream for the streaming/error-timing tradeoffs.
+//
+// Parameters:
+//   - tpl: A pointer to the text/template.Template to be used for the alternative body.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the alternative body part.
+//
+// Returns:
+//   - An error if the template is nil, otherwise nil.
+func (m *Msg) AddAlternativeTextTemplateStream(tpl *tt.Template, data interface{}, opts ...PartOption) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	m.AddAlternativeWriter(TypeTextPlain, writeFuncFromTextTemplate(tpl, data), opts...)
+	return nil
+}
+
 // AddAlternativeHTMLTemplate sets the alternative body of the message to an html/template.Template output.
 //
 // The content type will be set to "text/html" automatically. This method executes the provided HTML template
@@ -127,6 +237,12 @@ func (m *Msg) AddAlternativeHTMLTemplate(tpl *ht.Template, data interface{}, opt
 	if tpl == nil {
 		return errors.New(errTplPointerNil)
 	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	if hasLazyTemplateOption(opts) {
+		return m.AddAlternativeHTMLTemplateStream(tpl, data, opts...)
+	}
 	buffer := bytes.NewBuffer(nil)
 	if err := tpl.Execute(buffer, data); err != nil {
 		return fmt.Errorf(errTplExecuteFailed, err)
@@ -136,6 +252,34 @@ func (m *Msg) AddAlternativeHTMLTemplate(tpl *ht.Template, data interface{}, opt
 	return nil
 }
 
+// AddAlternativeHTMLTemplateStream adds the output of an html/template.Template pointer as an alternative
+// body, same as AddAlternativeHTMLTemplate, but without rendering the template into memory upfront. See
+// SetBodyHTMLTemplateStream for the streaming/error-timing tradeoffs.
+//
+// Parameters:
+//   - tpl: A pointer to the html/template.Template to be used for the alternative body.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the alternative body part.
+//
+// Returns:
+//   - An error if the template is nil, otherwise nil.
+func (m *Msg) AddAlternativeHTMLTemplateStream(tpl *ht.Template, data interface{}, opts ...PartOption) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if options := extractTemplateOptions(opts); len(options) > 0 {
+		tpl = tpl.Option(options...)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	m.AddAlternativeWriter(TypeTextHTML, writeFuncFromHTMLTemplate(tpl, data), opts...)
+	return nil
+}
+
 // AttachTextTemplate adds the output of a text/template.Template pointer as a File attachment to the Msg.
 //
 // This method allows you to attach the rendered output of a text template as a file to the message.
@@ -164,6 +308,39 @@ func (m *Msg) AttachTextTemplate(
 	return nil
 }
 
+// AttachTextTemplateLazy adds a text/template.Template pointer as a File attachment to the Msg, same as
+// AttachTextTemplate, but without rendering the template into memory upfront. The template is executed
+// directly into the MIME writer when the message is serialized, so a template execution error surfaces
+// at send/write time rather than at the time this method is called.
+//
+// Parameters:
+//   - name: The name of the file to be attached.
+//   - tpl: A pointer to the text/template.Template to be executed for the attachment.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the attachment.
+//
+// Returns:
+//   - An error if tpl is nil, otherwise nil.
+//
+// References:
+//   - https://datatracker.ietf.org/doc/html/rfc2183
+func (m *Msg) AttachTextTemplateLazy(
+	name string, tpl *tt.Template, data interface{}, opts ...FileOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	file := fileFromTemplate(name, writeFuncFromTextTemplate(tpl, data))
+	m.attachments = m.appendFile(m.attachments, file, opts...)
+	return nil
+}
+
 // AttachHTMLTemplate adds the output of a html/template.Template pointer as a File attachment to the Msg.
 //
 // This method allows you to attach the rendered output of an HTML template as a file to the message.
@@ -192,6 +369,38 @@ func (m *Msg) AttachHTMLTemplate(
 	return nil
 }
 
+// AttachHTMLTemplateLazy adds an html/template.Template pointer as a File attachment to the Msg, same as
+// AttachHTMLTemplate, but without rendering the template into memory upfront. See AttachTextTemplateLazy
+// for the streaming/error-timing tradeoffs.
+//
+// Parameters:
+//   - name: The name of the file to be attached.
+//   - tpl: A pointer to the html/template.Template to be executed for the attachment.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the attachment.
+//
+// Returns:
+//   - An error if tpl is nil, otherwise nil.
+//
+// References:
+//   - https://datatracker.ietf.org/doc/html/rfc2183
+func (m *Msg) AttachHTMLTemplateLazy(
+	name string, tpl *ht.Template, data interface{}, opts ...FileOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	file := fileFromTemplate(name, writeFuncFromHTMLTemplate(tpl, data))
+	m.attachments = m.appendFile(m.attachments, file, opts...)
+	return nil
+}
+
 // EmbedTextTemplate adds the output of a text/template.Template pointer as an embedded File to the Msg.
 //
 // This method embeds the rendered output of a text template into the email message. The template is
@@ -220,6 +429,38 @@ func (m *Msg) EmbedTextTemplate(
 	return nil
 }
 
+// EmbedTextTemplateLazy adds a text/template.Template pointer as an embedded File to the Msg, same as
+// EmbedTextTemplate, but without rendering the template into memory upfront. See AttachTextTemplateLazy
+// for the streaming/error-timing tradeoffs.
+//
+// Parameters:
+//   - name: The name of the embedded file.
+//   - tpl: A pointer to the text/template.Template to be executed for the embedded content.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the embedded file.
+//
+// Returns:
+//   - An error if tpl is nil, otherwise nil.
+//
+// References:
+//   - https://datatracker.ietf.org/doc/html/rfc2183
+func (m *Msg) EmbedTextTemplateLazy(
+	name string, tpl *tt.Template, data interface{}, opts ...FileOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	file := fileFromTemplate(name, writeFuncFromTextTemplate(tpl, data))
+	m.embeds = m.appendFile(m.embeds, file, opts...)
+	return nil
+}
+
 // EmbedHTMLTemplate adds the output of a html/template.Template pointer as an embedded File to the Msg.
 //
 // This method embeds the rendered output of an HTML template into the email message. The template is
@@ -248,6 +489,38 @@ func (m *Msg) EmbedHTMLTemplate(
 	return nil
 }
 
+// EmbedHTMLTemplateLazy adds an html/template.Template pointer as an embedded File to the Msg, same as
+// EmbedHTMLTemplate, but without rendering the template into memory upfront. See AttachTextTemplateLazy
+// for the streaming/error-timing tradeoffs.
+//
+// Parameters:
+//   - name: The name of the embedded file.
+//   - tpl: A pointer to the html/template.Template to be executed for the embedded content.
+//   - data: The data to populate the template.
+//   - opts: Optional parameters for customizing the embedded file.
+//
+// Returns:
+//   - An error if tpl is nil, otherwise nil.
+//
+// References:
+//   - https://datatracker.ietf.org/doc/html/rfc2183
+func (m *Msg) EmbedHTMLTemplateLazy(
+	name string, tpl *ht.Template, data interface{}, opts ...FileOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	m.registerTemplateValidator(func() error {
+		if err := tpl.Execute(io.Discard, data); err != nil {
+			return fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return nil
+	})
+	file := fileFromTemplate(name, writeFuncFromHTMLTemplate(tpl, data))
+	m.embeds = m.appendFile(m.embeds, file, opts...)
+	return nil
+}
+
 // fileFromTextTemplate returns a File pointer from a given text/template.Template.
 //
 // This method executes the provided text template with the given data and creates a File structure
@@ -303,3 +576,82 @@ func fileFromHTMLTemplate(name string, tpl *ht.Template, data interface{}) (*Fil
 	}
 	return fileFromReader(name, &buffer)
 }
+
+// countingWriter wraps an io.Writer and tracks the number of bytes successfully written to it, so that
+// a streaming write func can report a byte count the same way writeFuncFromBuffer does for a fully
+// buffered one.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write implements io.Writer.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeFuncFromTextTemplate returns a write func that executes tpl against data directly into the
+// io.Writer it is handed, instead of rendering into an intermediate buffer. It is the text/template
+// counterpart used by the ...Stream and ...Lazy template methods.
+func writeFuncFromTextTemplate(tpl *tt.Template, data interface{}) func(io.Writer) (int64, error) {
+	return func(w io.Writer) (int64, error) {
+		counter := &countingWriter{w: w}
+		if err := tpl.Execute(counter, data); err != nil {
+			return counter.n, fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return counter.n, nil
+	}
+}
+
+// writeFuncFromHTMLTemplate returns a write func that executes tpl against data directly into the
+// io.Writer it is handed, instead of rendering into an intermediate buffer. It is the html/template
+// counterpart used by the ...Stream and ...Lazy template methods.
+func writeFuncFromHTMLTemplate(tpl *ht.Template, data interface{}) func(io.Writer) (int64, error) {
+	return func(w io.Writer) (int64, error) {
+		counter := &countingWriter{w: w}
+		if err := tpl.Execute(counter, data); err != nil {
+			return counter.n, fmt.Errorf(errTplExecuteFailed, err)
+		}
+		return counter.n, nil
+	}
+}
+
+// fileFromTemplate returns a File pointer whose content is produced lazily by writeFunc when the MIME
+// writer pulls the part at serialization time, rather than being rendered upfront. Unlike
+// fileFromTextTemplate/fileFromHTMLTemplate, the content is never buffered here, so its content type
+// cannot be sniffed in advance; callers who need a specific content type should set it explicitly via
+// a FileOption.
+func fileFromTemplate(name string, writeFunc func(io.Writer) (int64, error)) *File {
+	return &File{
+		Name:   name,
+		Writer: writeFunc,
+	}
+}
+
+// lazyTemplatePartOption is the concrete PartOption value returned by WithLazyTemplate. Because
+// PartOption is a plain function type, the template methods in this file recognize it by comparing
+// function pointers via reflection rather than by any field on Part - it carries no part-level
+// configuration and is never actually applied to a Part.
+func lazyTemplatePartOption(*Part) {}
+
+// WithLazyTemplate instructs SetBodyTextTemplate, SetBodyHTMLTemplate, AddAlternativeTextTemplate and
+// AddAlternativeHTMLTemplate to execute their template lazily, directly into the io.Writer supplied at
+// serialization time, instead of rendering it into memory upfront. Passing it is equivalent to calling
+// the corresponding ...Stream method directly; it exists so callers can opt into streaming without
+// changing which method they call.
+func WithLazyTemplate() PartOption {
+	return lazyTemplatePartOption
+}
+
+// hasLazyTemplateOption reports whether opts contains the marker returned by WithLazyTemplate.
+func hasLazyTemplateOption(opts []PartOption) bool {
+	marker := reflect.ValueOf(PartOption(lazyTemplatePartOption)).Pointer()
+	for _, opt := range opts {
+		if opt != nil && reflect.ValueOf(opt).Pointer() == marker {
+			return true
+		}
+	}
+	return false
+}
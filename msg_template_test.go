@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"bytes"
+	ht "html/template"
+	"testing"
+	tt "text/template"
+)
+
+func TestWriteFuncFromTextTemplate(t *testing.T) {
+	tpl := tt.Must(tt.New("test").Parse("hello {{.Name}}"))
+	writeFunc := writeFuncFromTextTemplate(tpl, struct{ Name string }{Name: "world"})
+
+	var buf bytes.Buffer
+	n, err := writeFunc(&buf)
+	if err != nil {
+		t.Fatalf("writeFunc() returned error: %v", err)
+	}
+	if want := "hello world"; buf.String() != want {
+		t.Errorf("writeFunc() wrote %q, want %q", buf.String(), want)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("writeFunc() returned n = %d, want %d", n, buf.Len())
+	}
+}
+
+func TestWriteFuncFromTextTemplateError(t *testing.T) {
+	tpl := tt.Must(tt.New("test").Option("missingkey=error").Parse("{{.Missing}}"))
+	writeFunc := writeFuncFromTextTemplate(tpl, struct{}{})
+
+	var buf bytes.Buffer
+	if _, err := writeFunc(&buf); err == nil {
+		t.Fatal("writeFunc() expected error for missing field, got nil")
+	}
+}
+
+func TestWriteFuncFromHTMLTemplate(t *testing.T) {
+	tpl := ht.Must(ht.New("test").Parse("<b>{{.Name}}</b>"))
+	writeFunc := writeFuncFromHTMLTemplate(tpl, struct{ Name string }{Name: "world"})
+
+	var buf bytes.Buffer
+	if _, err := writeFunc(&buf); err != nil {
+		t.Fatalf("writeFunc() returned error: %v", err)
+	}
+	if want := "<b>world</b>"; buf.String() != want {
+		t.Errorf("writeFunc() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCountingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	counter := &countingWriter{w: &buf}
+	n, err := counter.Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if n != 5 || counter.n != 5 {
+		t.Errorf("Write() = (%d, _), counter.n = %d, want 5 for both", n, counter.n)
+	}
+	if _, err := counter.Write([]byte(" world")); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if counter.n != 11 {
+		t.Errorf("counter.n = %d after two writes, want 11", counter.n)
+	}
+}
+
+func TestFileFromTemplate(t *testing.T) {
+	tpl := tt.Must(tt.New("test").Parse("report for {{.Name}}"))
+	file := fileFromTemplate("report.txt", writeFuncFromTextTemplate(tpl, struct{ Name string }{Name: "Jane"}))
+
+	if file.Name != "report.txt" {
+		t.Errorf("file.Name = %q, want %q", file.Name, "report.txt")
+	}
+	var buf bytes.Buffer
+	if _, err := file.Writer(&buf); err != nil {
+		t.Fatalf("file.Writer() returned error: %v", err)
+	}
+	if want := "report for Jane"; buf.String() != want {
+		t.Errorf("file.Writer() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWithLazyTemplateDetection(t *testing.T) {
+	opts := []PartOption{WithLazyTemplate()}
+	if !hasLazyTemplateOption(opts) {
+		t.Error("hasLazyTemplateOption() = false for opts containing WithLazyTemplate(), want true")
+	}
+
+	ordinary := func(*Part) {}
+	if hasLazyTemplateOption([]PartOption{ordinary}) {
+		t.Error("hasLazyTemplateOption() = true for opts not containing WithLazyTemplate(), want false")
+	}
+
+	if hasLazyTemplateOption(nil) {
+		t.Error("hasLazyTemplateOption(nil) = true, want false")
+	}
+}
+
+func TestSetBodyTextTemplateStreamDefersExecution(t *testing.T) {
+	m := &Msg{}
+	tpl := tt.Must(tt.New("test").Option("missingkey=error").Parse("{{.Missing}}"))
+
+	if err := m.SetBodyTextTemplateStream(tpl, struct{}{}); err != nil {
+		t.Fatalf("SetBodyTextTemplateStream() returned error = %v, want nil (errors surface at validate/serialize time)", err)
+	}
+	if len(m.templateValidators) != 1 {
+		t.Fatalf("SetBodyTextTemplateStream() registered %d validators, want 1", len(m.templateValidators))
+	}
+	if err := m.ValidateTemplates(); err == nil {
+		t.Error("ValidateTemplates() = nil for a template referencing a missing field, want error")
+	}
+}
+
+func TestSetBodyHTMLTemplateStreamDefersExecution(t *testing.T) {
+	m := &Msg{}
+	tpl := ht.Must(ht.New("test").Parse("<b>{{.Name}}</b>"))
+
+	if err := m.SetBodyHTMLTemplateStream(tpl, struct{ Name string }{Name: "Jane"}); err != nil {
+		t.Fatalf("SetBodyHTMLTemplateStream() returned error = %v, want nil", err)
+	}
+	if len(m.templateValidators) != 1 {
+		t.Fatalf("SetBodyHTMLTemplateStream() registered %d validators, want 1", len(m.templateValidators))
+	}
+	if err := m.ValidateTemplates(); err != nil {
+		t.Errorf("ValidateTemplates() = %v for a well-formed template, want nil", err)
+	}
+}
+
+func TestSetBodyTextTemplateNilTemplate(t *testing.T) {
+	m := &Msg{}
+	if err := m.SetBodyTextTemplate(nil, nil); err == nil {
+		t.Error("SetBodyTextTemplate(nil, ...) = nil error, want non-nil")
+	}
+}
@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	ht "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	tt "text/template"
+)
+
+// Names of the well-known template sections a TemplateBundle looks for when it is parsed and
+// when it is applied to a Msg via Msg.ApplyTemplate.
+const (
+	tplSectionSubject     = "subject"
+	tplSectionText        = "text"
+	tplSectionHTML        = "html"
+	tplSectionHeaders     = "headers"
+	tplSectionAttachments = "attachments"
+)
+
+// errTplBundleNil is returned whenever a nil *TemplateBundle is passed to a function or method
+// that requires one.
+var errTplBundleNil = errors.New("template bundle pointer is nil")
+
+// TemplateBundle represents a complete, reusable email template. Unlike a bare text/template.Template
+// or html/template.Template, which only ever produces a single body fragment, a TemplateBundle parses
+// a set of named sections - "subject", "text", "html", "headers" and "attachments" - out of one or more
+// template files and applies all of them to a Msg in a single call to Msg.ApplyTemplate.
+//
+// The "headers" section, if present, is expected to render RFC-822-style "Name: Value" lines (From, To,
+// Cc, Bcc, Reply-To, In-Reply-To, Subject, ...), one per line. The "attachments" section, if present, is
+// expected to render one file path per line; each path is attached to the Msg via AttachFile.
+//
+// A TemplateBundle is safe to reuse across multiple calls to Msg.ApplyTemplate and across goroutines,
+// as long as the underlying text/template.Template and html/template.Template are not mutated concurrently
+// (which Funcs does).
+type TemplateBundle struct {
+	name string
+	text *tt.Template
+	html *ht.Template
+}
+
+// NewTemplateBundle parses the given template files into a new TemplateBundle. The name is used both as
+// the Template name passed to template.New and as the key under which LoadTemplateCatalog stores the
+// bundle. The files are parsed twice: once as a text/template.Template (used for the "subject", "text",
+// "headers" and "attachments" sections) and once as an html/template.Template (used for the "html"
+// section). Either parse may legitimately fail to find some of the optional sections; only a syntax
+// error or an unreadable file results in an error being returned.
+//
+// Parameters:
+//   - name: The name assigned to the bundle and to the underlying templates.
+//   - files: One or more template file paths to parse. At least one is required.
+//
+// Returns:
+//   - A pointer to the parsed TemplateBundle.
+//   - An error if no files are given or if either template fails to parse.
+func NewTemplateBundle(name string, files ...string) (*TemplateBundle, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no template files given")
+	}
+	text, err := tt.New(name).ParseFiles(files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text template bundle: %w", err)
+	}
+	html, err := ht.New(name).ParseFiles(files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html template bundle: %w", err)
+	}
+	return &TemplateBundle{name: name, text: text, html: html}, nil
+}
+
+// Funcs registers the given template.FuncMap on the bundle's underlying text and html templates, for
+// every section of the bundle ("subject", "text", "html", "headers" and "attachments"), so the functions
+// must have signatures accepted by both text/template and html/template. Funcs returns the bundle so
+// calls can be chained onto NewTemplateBundle. NewTemplateBundle has already parsed the files, and
+// function names are checked at parse time, so a section that calls a function other than the template
+// built-ins fails to parse there; Funcs only rebinds names the parsed sections already know and does
+// not make a new function callable from them.
+//
+// Parameters:
+//   - funcMap: The template.FuncMap to merge into the bundle's templates.
+//
+// Returns:
+//   - The same *TemplateBundle, for chaining.
+func (b *TemplateBundle) Funcs(funcMap tt.FuncMap) *TemplateBundle {
+	if b == nil {
+		return b
+	}
+	b.text = b.text.Funcs(funcMap)
+	b.html = b.html.Funcs(htmlTemplateFuncs(funcMap))
+	return b
+}
+
+// hasSection reports whether the bundle's text template defines a section with the given name.
+func (b *TemplateBundle) hasSection(name string) bool {
+	return b.text.Lookup(name) != nil
+}
+
+// renderSection executes the named section of the bundle's text template against data and returns its
+// trimmed output. The second return value is false if the section is not defined, in which case the
+// section is simply skipped by the caller.
+func (b *TemplateBundle) renderSection(name string, data interface{}) (string, bool, error) {
+	tpl := b.text.Lookup(name)
+	if tpl == nil {
+		return "", false, nil
+	}
+	buffer := strings.Builder{}
+	if err := tpl.Execute(&buffer, data); err != nil {
+		return "", true, fmt.Errorf(errTplExecuteFailed, err)
+	}
+	return strings.TrimSpace(buffer.String()), true, nil
+}
+
+// LoadTemplateCatalog parses every "*.tmpl" file in dir as its own TemplateBundle and returns them keyed
+// by their base file name without extension, so that callers can pick a template by name (e.g.
+// catalog["welcome"]) instead of tracking individual file paths.
+//
+// Parameters:
+//   - dir: The directory to scan for "*.tmpl" files.
+//
+// Returns:
+//   - A map of template name to *TemplateBundle.
+//   - An error if the directory cannot be read or a template fails to parse.
+func LoadTemplateCatalog(dir string) (map[string]*TemplateBundle, error) {
+	if _, err := os.ReadDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob template directory: %w", err)
+	}
+	catalog := make(map[string]*TemplateBundle, len(matches))
+	for _, file := range matches {
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		bundle, err := NewTemplateBundle(name, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load template %q: %w", file, err)
+		}
+		catalog[name] = bundle
+	}
+	return catalog, nil
+}
+
+// ApplyTemplate executes every section of bundle against data and applies the results to the Msg in one
+// shot: the "subject" section sets the Subject, the "headers" section is parsed as RFC-822-style
+// "Name: Value" lines and applied to the relevant address/generic headers, the "text" and "html" sections
+// are set as the text and HTML alternatives respectively, and the "attachments" section is parsed as one
+// file path per line and attached via AttachFile. Any section that bundle does not define is skipped.
+//
+// Parameters:
+//   - bundle: The TemplateBundle to execute. Must not be nil.
+//   - data: The data to populate every section of the bundle with.
+//
+// Returns:
+//   - An error if bundle is nil, if any section fails to execute, or if an attachment cannot be read.
+func (m *Msg) ApplyTemplate(bundle *TemplateBundle, data interface{}) error {
+	if bundle == nil {
+		return errTplBundleNil
+	}
+
+	if subject, ok, err := bundle.renderSection(tplSectionSubject, data); err != nil {
+		return err
+	} else if ok {
+		m.Subject(subject)
+	}
+
+	if headers, ok, err := bundle.renderSection(tplSectionHeaders, data); err != nil {
+		return err
+	} else if ok {
+		if err := m.applyTemplateHeaders(headers); err != nil {
+			return err
+		}
+	}
+
+	if ok := bundle.hasSection(tplSectionText); ok {
+		if err := m.SetBodyTextTemplate(bundle.text.Lookup(tplSectionText), data); err != nil {
+			return fmt.Errorf("failed to set text body from bundle: %w", err)
+		}
+	}
+
+	if htmlTpl := bundle.html.Lookup(tplSectionHTML); htmlTpl != nil {
+		opts := []PartOption{}
+		if bundle.hasSection(tplSectionText) {
+			if err := m.AddAlternativeHTMLTemplate(htmlTpl, data, opts...); err != nil {
+				return fmt.Errorf("failed to add html alternative from bundle: %w", err)
+			}
+		} else if err := m.SetBodyHTMLTemplate(htmlTpl, data); err != nil {
+			return fmt.Errorf("failed to set html body from bundle: %w", err)
+		}
+	}
+
+	if attachments, ok, err := bundle.renderSection(tplSectionAttachments, data); err != nil {
+		return err
+	} else if ok {
+		scanner := bufio.NewScanner(strings.NewReader(attachments))
+		for scanner.Scan() {
+			path := strings.TrimSpace(scanner.Text())
+			if path == "" {
+				continue
+			}
+			if err := m.AttachFile(path); err != nil {
+				return fmt.Errorf("failed to attach %q from bundle: %w", path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyTemplateHeaders parses the rendered "headers" section of a TemplateBundle - one "Name: Value"
+// line per header - and applies each line to the Msg, recognizing the well-known address headers
+// (From, To, Cc, Bcc, Reply-To) and Subject specially, and falling back to SetGenHeader for anything
+// else (In-Reply-To, References, custom X-headers, ...).
+func (m *Msg) applyTemplateHeaders(headers string) error {
+	scanner := bufio.NewScanner(strings.NewReader(headers))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return fmt.Errorf("invalid header line in template bundle: %q", line)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(name) {
+		case "subject":
+			m.Subject(value)
+		case "from":
+			if err := m.From(value); err != nil {
+				return fmt.Errorf("failed to set From header from template bundle: %w", err)
+			}
+		case "to":
+			if err := m.To(value); err != nil {
+				return fmt.Errorf("failed to set To header from template bundle: %w", err)
+			}
+		case "cc":
+			if err := m.Cc(value); err != nil {
+				return fmt.Errorf("failed to set Cc header from template bundle: %w", err)
+			}
+		case "bcc":
+			if err := m.Bcc(value); err != nil {
+				return fmt.Errorf("failed to set Bcc header from template bundle: %w", err)
+			}
+		case "reply-to":
+			if err := m.ReplyTo(value); err != nil {
+				return fmt.Errorf("failed to set Reply-To header from template bundle: %w", err)
+			}
+		default:
+			m.SetGenHeader(Header(name), value)
+		}
+	}
+	return nil
+}
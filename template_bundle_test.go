@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const bundleTestTemplate = `
+{{define "subject"}}Welcome, {{.Name}}{{end}}
+{{define "headers"}}X-Campaign: {{.Campaign}}{{end}}
+{{define "text"}}Hello {{.Name}}, welcome aboard.{{end}}
+{{define "html"}}<p>Hello {{.Name}}, welcome aboard.</p>{{end}}
+`
+
+type bundleTestData struct {
+	Name     string
+	Campaign string
+}
+
+func writeBundleTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write test template %q: %v", path, err)
+	}
+	return path
+}
+
+func TestNewTemplateBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBundleTestFile(t, dir, "welcome.tmpl", bundleTestTemplate)
+
+	bundle, err := NewTemplateBundle("welcome", path)
+	if err != nil {
+		t.Fatalf("NewTemplateBundle() returned error: %v", err)
+	}
+	if !bundle.hasSection(tplSectionSubject) || !bundle.hasSection(tplSectionText) {
+		t.Error("bundle is missing expected sections parsed from the template file")
+	}
+	if bundle.hasSection(tplSectionAttachments) {
+		t.Error("bundle reports an attachments section that was never defined")
+	}
+
+	if _, err := NewTemplateBundle("empty"); err == nil {
+		t.Error("NewTemplateBundle() with no files = nil error, want non-nil")
+	}
+}
+
+func TestRenderSection(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBundleTestFile(t, dir, "welcome.tmpl", bundleTestTemplate)
+
+	bundle, err := NewTemplateBundle("welcome", path)
+	if err != nil {
+		t.Fatalf("NewTemplateBundle() returned error: %v", err)
+	}
+
+	data := bundleTestData{Name: "Jane", Campaign: "spring-sale"}
+	subject, ok, err := bundle.renderSection(tplSectionSubject, data)
+	if err != nil || !ok {
+		t.Fatalf("renderSection(subject) = (%q, %v, %v), want a rendered value", subject, ok, err)
+	}
+	if want := "Welcome, Jane"; subject != want {
+		t.Errorf("renderSection(subject) = %q, want %q", subject, want)
+	}
+
+	if _, ok, err := bundle.renderSection(tplSectionAttachments, data); err != nil || ok {
+		t.Errorf("renderSection(attachments) = (_, %v, %v), want (false, nil) for an undefined section", ok, err)
+	}
+}
+
+func TestLoadTemplateCatalog(t *testing.T) {
+	dir := t.TempDir()
+	writeBundleTestFile(t, dir, "welcome.tmpl", bundleTestTemplate)
+	writeBundleTestFile(t, dir, "reminder.tmpl", `{{define "subject"}}Reminder{{end}}`)
+	writeBundleTestFile(t, dir, "notes.txt", "not a template, should be ignored")
+
+	catalog, err := LoadTemplateCatalog(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplateCatalog() returned error: %v", err)
+	}
+	if len(catalog) != 2 {
+		t.Fatalf("LoadTemplateCatalog() returned %d bundles, want 2", len(catalog))
+	}
+	if _, ok := catalog["welcome"]; !ok {
+		t.Error(`LoadTemplateCatalog() is missing the "welcome" bundle`)
+	}
+	if _, ok := catalog["reminder"]; !ok {
+		t.Error(`LoadTemplateCatalog() is missing the "reminder" bundle`)
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBundleTestFile(t, dir, "welcome.tmpl", bundleTestTemplate)
+
+	bundle, err := NewTemplateBundle("welcome", path)
+	if err != nil {
+		t.Fatalf("NewTemplateBundle() returned error: %v", err)
+	}
+
+	m := &Msg{}
+	data := bundleTestData{Name: "Jane", Campaign: "spring-sale"}
+	if err := m.ApplyTemplate(bundle, data); err != nil {
+		t.Fatalf("ApplyTemplate() returned error: %v", err)
+	}
+	if got := m.GetGenHeader(Header("X-Campaign")); len(got) != 1 || got[0] != "spring-sale" {
+		t.Errorf("GetGenHeader(X-Campaign) = %v, want [spring-sale]", got)
+	}
+
+	if err := m.ApplyTemplate(nil, data); err == nil {
+		t.Error("ApplyTemplate(nil, ...) = nil error, want non-nil")
+	}
+}
+
+// TestApplyTemplateAttachments is a regression test for the bug where ApplyTemplate discarded the error
+// returned by AttachFile, so a bad path rendered into the "attachments" section was silently dropped
+// despite the method's doc comment promising an error "if ... an attachment cannot be read".
+func TestApplyTemplateAttachments(t *testing.T) {
+	dir := t.TempDir()
+	attachment := writeBundleTestFile(t, dir, "invoice.pdf", "not a real pdf, just test content")
+
+	okTemplate := `{{define "attachments"}}` + attachment + `{{end}}`
+	path := writeBundleTestFile(t, dir, "withattachment.tmpl", okTemplate)
+	bundle, err := NewTemplateBundle("withattachment", path)
+	if err != nil {
+		t.Fatalf("NewTemplateBundle() returned error: %v", err)
+	}
+
+	m := &Msg{}
+	if err := m.ApplyTemplate(bundle, bundleTestData{}); err != nil {
+		t.Fatalf("ApplyTemplate() with a valid attachment path returned error: %v", err)
+	}
+	if len(m.attachments) != 1 {
+		t.Fatalf("ApplyTemplate() attached %d files, want 1", len(m.attachments))
+	}
+
+	badTemplate := `{{define "attachments"}}` + filepath.Join(dir, "does-not-exist.pdf") + `{{end}}`
+	badPath := writeBundleTestFile(t, dir, "badattachment.tmpl", badTemplate)
+	badBundle, err := NewTemplateBundle("badattachment", badPath)
+	if err != nil {
+		t.Fatalf("NewTemplateBundle() returned error: %v", err)
+	}
+
+	bad := &Msg{}
+	if err := bad.ApplyTemplate(badBundle, bundleTestData{}); err == nil {
+		t.Error("ApplyTemplate() with a nonexistent attachment path = nil error, want non-nil")
+	}
+}
+
+func TestApplyTemplateHeaders(t *testing.T) {
+	m := &Msg{}
+	if err := m.applyTemplateHeaders("X-Custom: some-value\nX-Other: other-value"); err != nil {
+		t.Fatalf("applyTemplateHeaders() returned error: %v", err)
+	}
+	if got := m.GetGenHeader(Header("X-Custom")); len(got) != 1 || got[0] != "some-value" {
+		t.Errorf("GetGenHeader(X-Custom) = %v, want [some-value]", got)
+	}
+
+	if err := m.applyTemplateHeaders("not-a-valid-header-line"); err == nil {
+		t.Error("applyTemplateHeaders() with no colon = nil error, want non-nil")
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	ht "html/template"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode/utf8"
+	tt "text/template"
+)
+
+// TemplateFuncs is the baseline template.FuncMap that SetBodyTextTemplateWithFuncs and
+// SetBodyHTMLTemplateWithFuncs merge into the template they are given; the other template methods in this
+// package, including TemplateBundle, do not add it. It covers the handful of things reply/forward and report templates need over and over:
+// quoting a parent message body, hard-wrapping text, formatting dates and addresses, and looking up a
+// header on a parent message. The "exec" function is deliberately left out of TemplateFuncs and must be
+// added explicitly via WithTemplateExec, since it runs an external command.
+//
+// References:
+//   - https://pkg.go.dev/text/template#FuncMap
+var TemplateFuncs = tt.FuncMap{
+	"quote":         quoteLines,
+	"wrap":          wrapText,
+	"dateFormat":    dateFormat,
+	"rfc2822date":   rfc2822date,
+	"header":        headerOf,
+	"address":       formatAddress,
+	"trimSignature": trimSignature,
+}
+
+// quoteLines prefixes each line of s with "> ", the conventional quoting style for reply bodies. A
+// single trailing newline, if present, is stripped first so it does not produce a spurious trailing
+// "> " line with nothing after it.
+func quoteLines(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapText hard-wraps s at width columns, breaking only on word boundaries. Words longer than width are
+// left intact on their own line rather than being broken mid-word.
+func wrapText(width int, s string) string {
+	if width <= 0 {
+		return s
+	}
+	var out strings.Builder
+	for paraIdx, paragraph := range strings.Split(s, "\n") {
+		if paraIdx > 0 {
+			out.WriteByte('\n')
+		}
+		lineLen := 0
+		for wordIdx, word := range strings.Fields(paragraph) {
+			wordLen := utf8.RuneCountInString(word)
+			switch {
+			case wordIdx == 0:
+				out.WriteString(word)
+				lineLen = wordLen
+			case lineLen+1+wordLen > width:
+				out.WriteByte('\n')
+				out.WriteString(word)
+				lineLen = wordLen
+			default:
+				out.WriteByte(' ')
+				out.WriteString(word)
+				lineLen += 1 + wordLen
+			}
+		}
+	}
+	return out.String()
+}
+
+// dateFormat formats t using layout, the same reference layout accepted by time.Time.Format.
+func dateFormat(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+// rfc2822date formats t per RFC 2822/5322 (e.g. "Mon, 02 Jan 2006 15:04:05 -0700"), the layout used for
+// the message Date header.
+func rfc2822date(t time.Time) string {
+	return t.Format(time.RFC1123Z)
+}
+
+// headerOf looks up the first value of the given generic header (as set via SetGenHeader) on a parent
+// message, for use in reply/forward templates (e.g. {{header "Message-ID" .Parent}}). It returns an
+// empty string if msg is nil or the header is not set. Address headers such as From or To are not
+// searched, so they also yield an empty string.
+func headerOf(name string, msg *Msg) string {
+	if msg == nil {
+		return ""
+	}
+	values := msg.GetGenHeader(Header(name))
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// formatAddress formats addr per RFC 5322 (e.g. "Jane Doe <jane@example.com>"). It returns an empty
+// string if addr is nil.
+func formatAddress(addr *Address) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
+
+// trimSignature removes everything from the first "-- \n" signature delimiter onward, the de facto
+// standard marker (RFC 3676 style) for where a mail signature begins, so a quoted reply doesn't drag
+// along the parent's signature block.
+func trimSignature(s string) string {
+	if idx := strings.Index(s, "\n-- \n"); idx >= 0 {
+		return s[:idx]
+	}
+	if strings.HasPrefix(s, "-- \n") {
+		return ""
+	}
+	return s
+}
+
+// execOutput runs name with args and returns its trimmed standard output, for use by the "exec"
+// template function once enabled via WithTemplateExec.
+func execOutput(name string, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run %q: %w", name, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// WithTemplateExec returns a template.FuncMap identical to TemplateFuncs plus an "exec" function that
+// runs an external command and substitutes its trimmed standard output, e.g. {{exec "git" "rev-parse"
+// "HEAD"}}. It is opt-in and kept separate from TemplateFuncs because it lets a template run arbitrary
+// commands with the privileges of the process rendering it; only merge it into templates whose source
+// is trusted.
+func WithTemplateExec() tt.FuncMap {
+	funcs := make(tt.FuncMap, len(TemplateFuncs)+1)
+	for name, fn := range TemplateFuncs {
+		funcs[name] = fn
+	}
+	funcs["exec"] = execOutput
+	return funcs
+}
+
+// htmlTemplateFuncs converts funcs to an html/template.FuncMap, since
+// text/template.FuncMap and html/template.FuncMap are distinct types despite sharing the same
+// underlying map[string]interface{} shape.
+func htmlTemplateFuncs(funcs tt.FuncMap) ht.FuncMap {
+	htFuncs := make(ht.FuncMap, len(funcs))
+	for name, fn := range funcs {
+		htFuncs[name] = fn
+	}
+	return htFuncs
+}
+
+// SetBodyTextTemplateWithFuncs sets the body of the message from tpl, same as SetBodyTextTemplate, but
+// first merges TemplateFuncs (plus any additional FuncMaps given) into tpl via Template.Funcs. Function
+// names are checked when a template is parsed, so tpl can call a helper like {{quote .ParentBody}} or
+// {{rfc2822date .Sent}} only if that name was already defined on it before Parse (for example via
+// tpl.Funcs(mail.TemplateFuncs)); this method rebinds such names to TemplateFuncs and extra, and does not
+// make a helper callable that tpl was parsed without.
+//
+// Parameters:
+//   - tpl: A pointer to the text/template.Template to be used for the message body.
+//   - data: The data to populate the template.
+//   - extra: Additional template.FuncMap values to merge in alongside TemplateFuncs, applied in order,
+//     so a later FuncMap can override an earlier one (or TemplateFuncs itself) by function name.
+//   - opts: Optional parameters for customizing the body part.
+//
+// Returns:
+//   - An error if the template is nil or fails to execute, otherwise nil.
+func (m *Msg) SetBodyTextTemplateWithFuncs(
+	tpl *tt.Template, data interface{}, extra []tt.FuncMap, opts ...PartOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	tpl = tpl.Funcs(TemplateFuncs)
+	for _, funcs := range extra {
+		tpl = tpl.Funcs(funcs)
+	}
+	return m.SetBodyTextTemplate(tpl, data, opts...)
+}
+
+// SetBodyHTMLTemplateWithFuncs sets the body of the message from tpl, same as SetBodyHTMLTemplate, but
+// first merges TemplateFuncs (plus any additional FuncMaps given) into tpl via Template.Funcs. As with
+// SetBodyTextTemplateWithFuncs, tpl can call a helper like {{quote .ParentBody}} only if that name was
+// already defined on it before Parse; this method rebinds such names and does not make a helper callable
+// that tpl was parsed without.
+//
+// Parameters:
+//   - tpl: A pointer to the html/template.Template to be used for the message body.
+//   - data: The data to populate the template.
+//   - extra: Additional template.FuncMap values to merge in alongside TemplateFuncs, applied in order,
+//     so a later FuncMap can override an earlier one (or TemplateFuncs itself) by function name.
+//   - opts: Optional parameters for customizing the body part.
+//
+// Returns:
+//   - An error if the template is nil or fails to execute, otherwise nil.
+func (m *Msg) SetBodyHTMLTemplateWithFuncs(
+	tpl *ht.Template, data interface{}, extra []tt.FuncMap, opts ...PartOption,
+) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	tpl = tpl.Funcs(htmlTemplateFuncs(TemplateFuncs))
+	for _, funcs := range extra {
+		tpl = tpl.Funcs(htmlTemplateFuncs(funcs))
+	}
+	return m.SetBodyHTMLTemplate(tpl, data, opts...)
+}
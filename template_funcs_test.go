@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuoteLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "single line", in: "hello", want: "> hello"},
+		{name: "multiple lines", in: "hello\nworld", want: "> hello\n> world"},
+		{name: "single trailing newline stripped", in: "hello\nworld\n", want: "> hello\n> world"},
+		{name: "two trailing newlines keep one quoted blank line", in: "hello\n\n", want: "> hello\n> "},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := quoteLines(tc.in); got != tc.want {
+				t.Errorf("quoteLines(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		in    string
+		want  string
+	}{
+		{name: "short text untouched", width: 20, in: "hello world", want: "hello world"},
+		{name: "wraps on word boundary", width: 5, in: "hello world", want: "hello\nworld"},
+		{
+			name:  "unicode counted by rune not byte",
+			width: 11,
+			in:    "héllo wörld",
+			want:  "héllo wörld",
+		},
+		{name: "zero width returns input unchanged", width: 0, in: "hello world", want: "hello world"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := wrapText(tc.width, tc.in); got != tc.want {
+				t.Errorf("wrapText(%d, %q) = %q, want %q", tc.width, tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	when := time.Date(2026, time.July, 26, 15, 4, 5, 0, time.UTC)
+	if got, want := dateFormat("2006-01-02", when), "2026-07-26"; got != want {
+		t.Errorf("dateFormat() = %q, want %q", got, want)
+	}
+}
+
+func TestRFC2822Date(t *testing.T) {
+	when := time.Date(2026, time.July, 26, 15, 4, 5, 0, time.UTC)
+	got := rfc2822date(when)
+	if !strings.Contains(got, "26 Jul 2026") {
+		t.Errorf("rfc2822date() = %q, want it to contain %q", got, "26 Jul 2026")
+	}
+}
+
+func TestTrimSignature(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "no signature", in: "hello\nworld", want: "hello\nworld"},
+		{name: "signature stripped", in: "hello\n-- \nJane Doe", want: "hello"},
+		{name: "signature only", in: "-- \nJane Doe", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := trimSignature(tc.in); got != tc.want {
+				t.Errorf("trimSignature(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncsHasExpectedHelpers(t *testing.T) {
+	want := []string{"quote", "wrap", "dateFormat", "rfc2822date", "header", "address", "trimSignature"}
+	for _, name := range want {
+		if _, ok := TemplateFuncs[name]; !ok {
+			t.Errorf("TemplateFuncs is missing %q", name)
+		}
+	}
+	if _, ok := TemplateFuncs["exec"]; ok {
+		t.Error("TemplateFuncs should not include \"exec\" by default")
+	}
+}
+
+func TestWithTemplateExecAddsExecWithoutMutatingTemplateFuncs(t *testing.T) {
+	funcs := WithTemplateExec()
+	if _, ok := funcs["exec"]; !ok {
+		t.Fatal("WithTemplateExec() result is missing \"exec\"")
+	}
+	if _, ok := TemplateFuncs["exec"]; ok {
+		t.Error("WithTemplateExec must not add \"exec\" to the shared TemplateFuncs map")
+	}
+	for name := range TemplateFuncs {
+		if _, ok := funcs[name]; !ok {
+			t.Errorf("WithTemplateExec() result is missing %q from TemplateFuncs", name)
+		}
+	}
+}
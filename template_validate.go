@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	"errors"
+	"fmt"
+	ht "html/template"
+	"io"
+	"reflect"
+	"runtime"
+	"sync"
+	tt "text/template"
+	"unsafe"
+)
+
+// ValidateTextTemplate executes tpl against a zero-valued instance of its expected data type and
+// discards the output, so that syntax errors and (with the "missingkey=error" option set on tpl) missing
+// field references surface before the template is ever used to send a message. dataType should be a
+// value of the same type that will later be passed as the data argument to SetBodyTextTemplate and
+// friends; only its type is used, its value is ignored.
+//
+// Parameters:
+//   - tpl: A pointer to the text/template.Template to validate. Must not be nil.
+//   - dataType: A value of the type the template will be executed against.
+//
+// Returns:
+//   - An error if tpl is nil or fails to execute against the dummy data, otherwise nil.
+func ValidateTextTemplate(tpl *tt.Template, dataType interface{}) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if err := tpl.Execute(io.Discard, dummyValue(dataType)); err != nil {
+		return fmt.Errorf(errTplExecuteFailed, err)
+	}
+	return nil
+}
+
+// ValidateHTMLTemplate executes tpl against a zero-valued instance of its expected data type and
+// discards the output, so that syntax errors and (with the "missingkey=error" option set on tpl) missing
+// field references surface before the template is ever used to send a message. dataType should be a
+// value of the same type that will later be passed as the data argument to SetBodyHTMLTemplate and
+// friends; only its type is used, its value is ignored.
+//
+// Parameters:
+//   - tpl: A pointer to the html/template.Template to validate. Must not be nil.
+//   - dataType: A value of the type the template will be executed against.
+//
+// Returns:
+//   - An error if tpl is nil or fails to execute against the dummy data, otherwise nil.
+func ValidateHTMLTemplate(tpl *ht.Template, dataType interface{}) error {
+	if tpl == nil {
+		return errors.New(errTplPointerNil)
+	}
+	if err := tpl.Execute(io.Discard, dummyValue(dataType)); err != nil {
+		return fmt.Errorf(errTplExecuteFailed, err)
+	}
+	return nil
+}
+
+// dummyValue returns a zero value of the same type as v, for use as the data argument when validating
+// a template that has not actually been populated with real data yet. A nil v yields nil, which is
+// valid input for a template that does not reference any fields.
+func dummyValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem()).Interface()
+	}
+	return reflect.Zero(t).Interface()
+}
+
+// ValidateTemplates executes every template registered against the Msg via one of the lazy/streaming
+// template methods (SetBodyTextTemplateStream, SetBodyHTMLTemplateStream, AddAlternativeTextTemplateStream,
+// AddAlternativeHTMLTemplateStream, AttachTextTemplateLazy, AttachHTMLTemplateLazy, EmbedTextTemplateLazy,
+// EmbedHTMLTemplateLazy) and reports the first error encountered. Eager template methods already execute
+// (and therefore validate) at call time, so they have nothing left to check here; this method exists to
+// give callers using the streaming/lazy methods the same fail-fast behavior without having to wait until
+// the message is actually serialized or sent.
+//
+// Calling this runs every registered template a second time in addition to the execution that happens
+// at serialization, since the output is discarded rather than cached (caching it would mean buffering
+// it in memory, which defeats the point of the streaming/lazy methods in the first place). For a
+// template using the "exec" function from WithTemplateExec, that means the external command it invokes
+// runs once here and once again when the message is actually serialized; callers relying on WithTemplateExec
+// should account for that when deciding whether to call ValidateTemplates before sending.
+//
+// Returns:
+//   - An error describing the first template that fails to execute, or nil if all validate cleanly.
+func (m *Msg) ValidateTemplates() error {
+	for _, validate := range m.templateValidators {
+		if err := validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerTemplateValidator appends a validation closure for a lazily-executed template part to the
+// Msg, so that Msg.ValidateTemplates can exercise it without waiting for serialization. The closure
+// re-executes the same template/data pair that serialization will later execute for real; see the
+// double-execution caveat on Msg.ValidateTemplates.
+func (m *Msg) registerTemplateValidator(validate func() error) {
+	m.templateValidators = append(m.templateValidators, validate)
+}
+
+// templateOptionRegistry associates the PartOption closures returned by WithTemplateOption with the
+// option string they were built from. A closure value can't carry extra fields the way a struct can,
+// so the registry - keyed by the address of the closure value - is what lets the template methods in this
+// package recover the option string that was passed to WithTemplateOption. Entries are intentionally
+// never removed: like every other PartOption in this package, a WithTemplateOption(...) result is a
+// stateless, freely-reusable value that callers may pass to more than one template method, so consuming
+// it on first use would silently stop applying the option on the second and later calls. The registry
+// therefore holds one entry, and keeps its closure alive, for every call to WithTemplateOption.
+//
+// reflect.Value.Pointer cannot serve as the key: for a func value it returns the code pointer, which is
+// the same for every closure built from the same literal, so every call to WithTemplateOption would
+// collide on one key. The closure value's own address is distinct per call only if the closure captures
+// something, so each call below allocates a fresh token and captures it (kept alive via runtime.KeepAlive).
+var templateOptionRegistry sync.Map // map[unsafe.Pointer]string
+
+// templateOptionKey returns the address of the closure value opt refers to, which is the key under which
+// WithTemplateOption registers it.
+func templateOptionKey(opt PartOption) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&opt)) //nolint:gosec
+}
+
+// WithTemplateOption returns a PartOption that applies text/template.Template.Option (or the
+// html/template equivalent) to a template-backed part before it is executed, e.g.
+// WithTemplateOption("missingkey=error") to turn a reference to an undefined field such as
+// "{{.FristName}}" into a hard execution error instead of silently rendering "<no value>". It is
+// recognized by SetBodyTextTemplate, SetBodyHTMLTemplate, AddAlternativeTextTemplate,
+// AddAlternativeHTMLTemplate and their ...Stream variants; passed anywhere else (including the
+// FileOption-based Attach/Embed template methods) it has no effect.
+//
+// Parameters:
+//   - option: The option string, as accepted by text/template.Template.Option / html/template.Template.Option.
+//
+// Returns:
+//   - A PartOption to be passed alongside a template method call.
+func WithTemplateOption(option string) PartOption {
+	token := new(byte)
+	marker := func(*Part) { runtime.KeepAlive(token) }
+	templateOptionRegistry.Store(templateOptionKey(marker), option)
+	return marker
+}
+
+// extractTemplateOptions pulls every option string registered via WithTemplateOption out of opts. Unlike
+// a consuming read, this leaves the registry entries in place so the same WithTemplateOption(...) result
+// can be reused across more than one template method call, matching the rest of this package's PartOption
+// values, which are all stateless and reusable.
+func extractTemplateOptions(opts []PartOption) []string {
+	var found []string
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if value, ok := templateOptionRegistry.Load(templateOptionKey(opt)); ok {
+			found = append(found, value.(string))
+		}
+	}
+	return found
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: The go-mail Authors
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !gomailnotpl
+
+package mail
+
+import (
+	ht "html/template"
+	"testing"
+	tt "text/template"
+)
+
+func TestValidateTextTemplate(t *testing.T) {
+	type data struct{ Name string }
+
+	ok := tt.Must(tt.New("test").Parse("hello {{.Name}}"))
+	if err := ValidateTextTemplate(ok, data{}); err != nil {
+		t.Errorf("ValidateTextTemplate() = %v for a valid template, want nil", err)
+	}
+
+	strict := tt.Must(tt.New("test").Option("missingkey=error").Parse("{{.Missing}}"))
+	if err := ValidateTextTemplate(strict, data{}); err == nil {
+		t.Error("ValidateTextTemplate() = nil for a template referencing a missing field, want error")
+	}
+
+	if err := ValidateTextTemplate(nil, data{}); err == nil {
+		t.Error("ValidateTextTemplate(nil, ...) = nil error, want non-nil")
+	}
+}
+
+func TestValidateHTMLTemplate(t *testing.T) {
+	type data struct{ Name string }
+
+	ok := ht.Must(ht.New("test").Parse("<b>{{.Name}}</b>"))
+	if err := ValidateHTMLTemplate(ok, data{}); err != nil {
+		t.Errorf("ValidateHTMLTemplate() = %v for a valid template, want nil", err)
+	}
+
+	if err := ValidateHTMLTemplate(nil, data{}); err == nil {
+		t.Error("ValidateHTMLTemplate(nil, ...) = nil error, want non-nil")
+	}
+}
+
+func TestDummyValue(t *testing.T) {
+	type data struct {
+		Name string
+		Age  int
+	}
+
+	if got := dummyValue(nil); got != nil {
+		t.Errorf("dummyValue(nil) = %v, want nil", got)
+	}
+
+	got := dummyValue(data{Name: "ignored", Age: 99})
+	if _, ok := got.(data); !ok {
+		t.Fatalf("dummyValue(data{}) returned %T, want data", got)
+	}
+	if got != (data{}) {
+		t.Errorf("dummyValue(data{...}) = %+v, want zero value", got)
+	}
+
+	gotPtr := dummyValue(&data{Name: "ignored"})
+	ptr, ok := gotPtr.(*data)
+	if !ok || ptr == nil {
+		t.Fatalf("dummyValue(&data{...}) = %T, want non-nil *data", gotPtr)
+	}
+	if *ptr != (data{}) {
+		t.Errorf("*dummyValue(&data{...}) = %+v, want zero value", *ptr)
+	}
+}
+
+// TestWithTemplateOptionDoesNotCollide is a regression test for the bug where WithTemplateOption's
+// marker closure captured nothing, so Go could produce the same function value across calls and two
+// outstanding calls would clobber each other's registry entry. Two concurrent, not-yet-consumed calls
+// must each keep their own option string recoverable.
+func TestWithTemplateOptionDoesNotCollide(t *testing.T) {
+	first := WithTemplateOption("missingkey=error")
+	second := WithTemplateOption("missingkey=zero")
+
+	firstOptions := extractTemplateOptions([]PartOption{first})
+	if len(firstOptions) != 1 || firstOptions[0] != "missingkey=error" {
+		t.Fatalf("extractTemplateOptions(first) = %v, want [missingkey=error]", firstOptions)
+	}
+
+	secondOptions := extractTemplateOptions([]PartOption{second})
+	if len(secondOptions) != 1 || secondOptions[0] != "missingkey=zero" {
+		t.Fatalf("extractTemplateOptions(second) = %v, want [missingkey=zero]", secondOptions)
+	}
+}
+
+// TestExtractTemplateOptionsIsReusable is a regression test for the bug where the registry entry was
+// removed via LoadAndDelete on first use, so a WithTemplateOption(...) result - unlike every other
+// PartOption in this package - silently stopped applying after being passed to one template method call.
+func TestExtractTemplateOptionsIsReusable(t *testing.T) {
+	opt := WithTemplateOption("missingkey=error")
+
+	first := extractTemplateOptions([]PartOption{opt})
+	if len(first) != 1 || first[0] != "missingkey=error" {
+		t.Fatalf("extractTemplateOptions() first call = %v, want [missingkey=error]", first)
+	}
+
+	second := extractTemplateOptions([]PartOption{opt})
+	if len(second) != 1 || second[0] != "missingkey=error" {
+		t.Errorf("extractTemplateOptions() second call = %v, want [missingkey=error] (option must be reusable)", second)
+	}
+}
+
+func TestExtractTemplateOptionsIgnoresUnrelatedOptions(t *testing.T) {
+	ordinary := func(*Part) {}
+	if got := extractTemplateOptions([]PartOption{ordinary, nil}); len(got) != 0 {
+		t.Errorf("extractTemplateOptions() = %v for non-template options, want none", got)
+	}
+}